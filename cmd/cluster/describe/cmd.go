@@ -0,0 +1,71 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package describe
+
+import (
+	"fmt"
+
+	clusterprovider "github.com/openshift-online/ocm-cli/pkg/cluster"
+	"github.com/openshift-online/ocm-cli/pkg/ocm"
+	"github.com/spf13/cobra"
+)
+
+var args struct {
+	output         string
+	failOnDegraded bool
+}
+
+// Cmd is the "cluster describe" command.
+var Cmd = &cobra.Command{
+	Use:     "cluster [flags] {CLUSTER_ID|NAME|EXTERNAL_ID}",
+	Aliases: []string{"clusters"},
+	Short:   "Show details of a cluster",
+	Long:    "Show the details of a cluster, identified by its ID, name or external ID.",
+	Args:    cobra.ExactArgs(1),
+	RunE:    run,
+}
+
+func init() {
+	Cmd.Flags().StringVarP(
+		&args.output,
+		"output",
+		"o",
+		clusterprovider.OutputTable,
+		"Output format, one of: table, json, yaml.",
+	)
+	Cmd.Flags().BoolVar(
+		&args.failOnDegraded,
+		"fail-on-degraded",
+		false,
+		"Exit with a non-zero status if the cluster's health assessment finds it degraded.",
+	)
+}
+
+func run(cmd *cobra.Command, argv []string) error {
+	connection, err := ocm.NewConnection().Build()
+	if err != nil {
+		return fmt.Errorf("can't create connection: %v", err)
+	}
+	defer connection.Close()
+
+	cluster, err := clusterprovider.GetCluster(connection, argv[0])
+	if err != nil {
+		return fmt.Errorf("can't retrieve cluster '%s': %v", argv[0], err)
+	}
+
+	return clusterprovider.PrintClusterDesctipion(connection, cluster, args.output, args.failOnDegraded)
+}
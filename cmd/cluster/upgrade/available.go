@@ -0,0 +1,90 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgrade
+
+import (
+	"fmt"
+
+	clusterprovider "github.com/openshift-online/ocm-cli/pkg/cluster"
+	"github.com/openshift-online/ocm-cli/pkg/ocm"
+	"github.com/spf13/cobra"
+)
+
+var availableCmd = &cobra.Command{
+	Use:   "available {CLUSTER_ID|NAME|EXTERNAL_ID}",
+	Short: "List available upgrades",
+	Long: "List every version a cluster could be upgraded to, grouped by whether it is a " +
+		"y-stream or z-stream upgrade. For HCP clusters, node pool upgrade eligibility is " +
+		"reported separately from control plane upgrades.",
+	Args: cobra.ExactArgs(1),
+	RunE: availableRun,
+}
+
+func init() {
+	Cmd.AddCommand(availableCmd)
+}
+
+func availableRun(cmd *cobra.Command, argv []string) error {
+	connection, err := ocm.NewConnection().Build()
+	if err != nil {
+		return fmt.Errorf("can't create connection: %v", err)
+	}
+	defer connection.Close()
+
+	cluster, err := clusterprovider.GetCluster(connection, argv[0])
+	if err != nil {
+		return fmt.Errorf("can't retrieve cluster '%s': %v", argv[0], err)
+	}
+
+	upgrades, err := clusterprovider.ListAvailableUpgrades(connection, cluster)
+	if err != nil {
+		return err
+	}
+	if len(upgrades) == 0 {
+		fmt.Println("no upgrades available")
+	} else {
+		fmt.Println("Y-stream:")
+		for _, upgrade := range upgrades {
+			if upgrade.Stream == clusterprovider.YStream {
+				fmt.Printf("  %s\n", upgrade.Version)
+			}
+		}
+		fmt.Println("Z-stream:")
+		for _, upgrade := range upgrades {
+			if upgrade.Stream == clusterprovider.ZStream {
+				fmt.Printf("  %s\n", upgrade.Version)
+			}
+		}
+	}
+
+	nodePools, err := clusterprovider.ListNodePoolUpgrades(connection, cluster)
+	if err != nil {
+		return err
+	}
+	if len(nodePools) > 0 {
+		fmt.Println("\nNode pools:")
+		for _, pool := range nodePools {
+			status := "up to date"
+			if pool.UpgradeEligible {
+				status = fmt.Sprintf("upgrade available (%s -> %s)", pool.Version, pool.ControlPlane)
+			}
+			fmt.Printf("  %s: %s\n", pool.ID, status)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgrade
+
+import (
+	"fmt"
+	"time"
+
+	clusterprovider "github.com/openshift-online/ocm-cli/pkg/cluster"
+	"github.com/openshift-online/ocm-cli/pkg/ocm"
+	"github.com/spf13/cobra"
+)
+
+var cancelArgs struct {
+	id string
+}
+
+var cancelCmd = &cobra.Command{
+	Use:   "cancel {CLUSTER_ID|NAME|EXTERNAL_ID}",
+	Short: "Cancel a pending cluster upgrade",
+	Long: "Cancel a pending upgrade policy for a cluster. If '--id' is not given, " +
+		"the nearest-in-time scheduled policy is cancelled.",
+	Args: cobra.ExactArgs(1),
+	RunE: cancelRun,
+}
+
+func init() {
+	cancelCmd.Flags().StringVar(
+		&cancelArgs.id,
+		"id",
+		"",
+		"ID of the upgrade policy to cancel. Defaults to the nearest-in-time policy.",
+	)
+}
+
+func cancelRun(cmd *cobra.Command, argv []string) error {
+	connection, err := ocm.NewConnection().Build()
+	if err != nil {
+		return fmt.Errorf("can't create connection: %v", err)
+	}
+	defer connection.Close()
+
+	cluster, err := clusterprovider.GetCluster(connection, argv[0])
+	if err != nil {
+		return fmt.Errorf("can't retrieve cluster '%s': %v", argv[0], err)
+	}
+
+	policy, err := clusterprovider.CancelUpgradePolicy(connection, cluster, cancelArgs.id)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Cancelled upgrade policy '%s' for version %s (was scheduled for %s)\n",
+		policy.ID(), policy.Version(), policy.NextRun().Format(time.RFC3339))
+	return nil
+}
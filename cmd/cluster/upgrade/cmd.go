@@ -0,0 +1,37 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package upgrade implements the "ocm cluster upgrade" tree of subcommands,
+// used to list, schedule and cancel cluster upgrade policies.
+package upgrade
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// Cmd is the root of the "cluster upgrade" subcommand tree. It is added as a
+// child of the "cluster" command.
+var Cmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Manage cluster upgrade policies",
+	Long:  "List, schedule and cancel upgrade policies for a cluster.",
+}
+
+func init() {
+	Cmd.AddCommand(listCmd)
+	Cmd.AddCommand(scheduleCmd)
+	Cmd.AddCommand(cancelCmd)
+}
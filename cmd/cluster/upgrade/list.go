@@ -0,0 +1,63 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgrade
+
+import (
+	"fmt"
+	"time"
+
+	clusterprovider "github.com/openshift-online/ocm-cli/pkg/cluster"
+	"github.com/openshift-online/ocm-cli/pkg/ocm"
+	"github.com/spf13/cobra"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list {CLUSTER_ID|NAME|EXTERNAL_ID}",
+	Short: "List upgrade policies",
+	Long:  "List every upgrade policy configured for a cluster.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  listRun,
+}
+
+func listRun(cmd *cobra.Command, argv []string) error {
+	connection, err := ocm.NewConnection().Build()
+	if err != nil {
+		return fmt.Errorf("can't create connection: %v", err)
+	}
+	defer connection.Close()
+
+	cluster, err := clusterprovider.GetCluster(connection, argv[0])
+	if err != nil {
+		return fmt.Errorf("can't retrieve cluster '%s': %v", argv[0], err)
+	}
+
+	policies, err := clusterprovider.GetUpgradePolicies(connection, cluster)
+	if err != nil {
+		return err
+	}
+	if len(policies) == 0 {
+		fmt.Println("no upgrade policies scheduled")
+		return nil
+	}
+
+	fmt.Printf("%-36s %-12s %-10s %s\n", "ID", "VERSION", "TYPE", "NEXT RUN")
+	for _, policy := range policies {
+		fmt.Printf("%-36s %-12s %-10s %s\n",
+			policy.ID(), policy.Version(), policy.ScheduleType(), policy.NextRun().Format(time.RFC3339))
+	}
+	return nil
+}
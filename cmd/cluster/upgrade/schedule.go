@@ -0,0 +1,107 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgrade
+
+import (
+	"fmt"
+	"time"
+
+	clusterprovider "github.com/openshift-online/ocm-cli/pkg/cluster"
+	"github.com/openshift-online/ocm-cli/pkg/ocm"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+)
+
+var scheduleArgs struct {
+	version      string
+	nextRun      string
+	scheduleType string
+}
+
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule {CLUSTER_ID|NAME|EXTERNAL_ID}",
+	Short: "Schedule a cluster upgrade",
+	Long:  "Schedule a new upgrade policy for a cluster.",
+	Args:  cobra.ExactArgs(1),
+	RunE:  scheduleRun,
+}
+
+func init() {
+	flags := scheduleCmd.Flags()
+	flags.StringVar(
+		&scheduleArgs.version,
+		"version",
+		"",
+		"Version to upgrade to.",
+	)
+	flags.StringVar(
+		&scheduleArgs.nextRun,
+		"next-run",
+		"",
+		"Time to schedule the upgrade for, in RFC3339 format.",
+	)
+	flags.StringVar(
+		&scheduleArgs.scheduleType,
+		"schedule-type",
+		string(cmv1.ScheduleTypeManual),
+		"Schedule type, one of 'manual' or 'automatic'.",
+	)
+}
+
+func scheduleRun(cmd *cobra.Command, argv []string) error {
+	if scheduleArgs.version == "" {
+		return fmt.Errorf("flag '--version' is required")
+	}
+	if scheduleArgs.nextRun == "" {
+		return fmt.Errorf("flag '--next-run' is required")
+	}
+	nextRun, err := time.Parse(time.RFC3339, scheduleArgs.nextRun)
+	if err != nil {
+		return fmt.Errorf("can't parse '--next-run' value '%s': %v", scheduleArgs.nextRun, err)
+	}
+
+	var scheduleType cmv1.ScheduleTypeValue
+	switch scheduleArgs.scheduleType {
+	case string(cmv1.ScheduleTypeManual):
+		scheduleType = cmv1.ScheduleTypeManual
+	case string(cmv1.ScheduleTypeAutomatic):
+		scheduleType = cmv1.ScheduleTypeAutomatic
+	default:
+		return fmt.Errorf("flag '--schedule-type' must be one of 'manual' or 'automatic'")
+	}
+
+	connection, err := ocm.NewConnection().Build()
+	if err != nil {
+		return fmt.Errorf("can't create connection: %v", err)
+	}
+	defer connection.Close()
+
+	cluster, err := clusterprovider.GetCluster(connection, argv[0])
+	if err != nil {
+		return fmt.Errorf("can't retrieve cluster '%s': %v", argv[0], err)
+	}
+
+	policy, err := clusterprovider.ScheduleUpgradePolicy(
+		connection, cluster, scheduleArgs.version, nextRun, scheduleType)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Scheduled upgrade policy '%s' for version %s at %s\n",
+		policy.ID(), policy.Version(), policy.NextRun().Format(time.RFC3339))
+	return nil
+}
@@ -0,0 +1,20 @@
+package clusterdata
+
+import (
+	"testing"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+func newTestCluster(t testing.TB, id string) *cmv1.Cluster {
+	t.Helper()
+
+	cluster, err := cmv1.NewCluster().
+		ID(id).
+		Subscription(cmv1.NewSubscription().ID("sub-" + id)).
+		Build()
+	if err != nil {
+		t.Fatalf("can't build test cluster: %v", err)
+	}
+	return cluster
+}
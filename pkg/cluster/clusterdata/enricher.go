@@ -0,0 +1,104 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clusterdata fetches the auxiliary data (subscription, account,
+// provision shard, upgrade policies) that make up a full cluster description,
+// running the individual fetches concurrently instead of one round-trip at a
+// time.
+package clusterdata
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hashicorp/go-multierror"
+	"golang.org/x/sync/errgroup"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	amv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// maxConcurrentEnrichers bounds how many enrichers run at once, so that a
+// large EnrichedCluster request can't open an unbounded number of
+// simultaneous connections to OCM.
+const maxConcurrentEnrichers = 4
+
+// EnrichedCluster bundles a cluster with the additional data fetched by a set
+// of Enrichers. Fields are left at their zero value when the corresponding
+// enricher failed before fetching them or had nothing to report.
+type EnrichedCluster struct {
+	Cluster         *cmv1.Cluster
+	Subscription    *amv1.Subscription
+	Account         *amv1.Account
+	Shard           string
+	UpgradePolicies []*cmv1.UpgradePolicy
+}
+
+// Enricher populates one part of an EnrichedCluster from OCM. Implementations
+// must only write to the field(s) they own, and must not read fields owned by
+// other enrichers, since a ParallelEnricher runs them concurrently.
+type Enricher interface {
+	Enrich(connection *sdk.Connection, cluster *cmv1.Cluster, enriched *EnrichedCluster) error
+}
+
+// ParallelEnricher runs a set of Enrichers concurrently against the same
+// cluster and aggregates their results into a single EnrichedCluster.
+type ParallelEnricher struct {
+	enrichers []Enricher
+}
+
+// NewParallelEnricher creates a ParallelEnricher that runs the given
+// enrichers.
+func NewParallelEnricher(enrichers ...Enricher) *ParallelEnricher {
+	return &ParallelEnricher{enrichers: enrichers}
+}
+
+// Enrich fetches the data for each configured Enricher concurrently and
+// returns the aggregated EnrichedCluster. If one or more enrichers fail, the
+// returned error is a *multierror.Error listing every failure; a failing
+// enricher leaves the fields it did not fetch at their zero value (whatever it
+// fetched before failing is kept), and the fields from enrichers that
+// succeeded are still populated, so a single 403 fetching the
+// account doesn't hide a failure fetching the shard.
+func (p *ParallelEnricher) Enrich(connection *sdk.Connection, cluster *cmv1.Cluster) (*EnrichedCluster, error) {
+	enriched := &EnrichedCluster{Cluster: cluster}
+
+	group, _ := errgroup.WithContext(context.Background())
+	group.SetLimit(maxConcurrentEnrichers)
+
+	var mu sync.Mutex
+	var errs *multierror.Error
+
+	for _, enricher := range p.enrichers {
+		enricher := enricher
+		group.Go(func() error {
+			err := enricher.Enrich(connection, cluster, enriched)
+			if err != nil {
+				mu.Lock()
+				errs = multierror.Append(errs, err)
+				mu.Unlock()
+			}
+			// Never return the error from the goroutine itself: doing so
+			// would cancel the group's context and abort enrichers that are
+			// still in flight. Failures are collected above instead.
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	return enriched, errs.ErrorOrNil()
+}
@@ -0,0 +1,46 @@
+package clusterdata
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/openshift-online/ocm-cli/internal/sdktest"
+)
+
+func BenchmarkParallelEnricherEnrich(b *testing.B) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/accounts_mgmt/v1/subscriptions/sub-123", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"Subscription","id":"sub-123","creator":{"id":"account-1"}}`)
+	})
+	mux.HandleFunc("/api/accounts_mgmt/v1/accounts/account-1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"Account","id":"account-1","username":"jdoe"}`)
+	})
+	mux.HandleFunc("/api/clusters_mgmt/v1/clusters/123/provision_shard", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"ProvisionShard","id":"shard-1","hive_config":{"server":"https://hive.example.com"}}`)
+	})
+	mux.HandleFunc("/api/clusters_mgmt/v1/clusters/123/upgrade_policies", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"UpgradePolicyList","items":[]}`)
+	})
+
+	connection, cleanup := sdktest.NewConnection(b, mux)
+	defer cleanup()
+
+	cluster := newTestCluster(b, "123")
+	enricher := NewParallelEnricher(
+		&SubscriptionEnricher{},
+		&ShardEnricher{},
+		&UpgradePolicyEnricher{},
+	)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := enricher.Enrich(connection, cluster); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
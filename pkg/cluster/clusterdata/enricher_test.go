@@ -0,0 +1,99 @@
+package clusterdata
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/openshift-online/ocm-cli/internal/sdktest"
+)
+
+func TestParallelEnricherAggregatesResults(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/accounts_mgmt/v1/subscriptions/sub-123", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"Subscription","id":"sub-123","creator":{"id":"account-1"}}`)
+	})
+	mux.HandleFunc("/api/accounts_mgmt/v1/accounts/account-1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"Account","id":"account-1","username":"jdoe","email":"jdoe@example.com"}`)
+	})
+	mux.HandleFunc("/api/clusters_mgmt/v1/clusters/123/provision_shard", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"ProvisionShard","id":"shard-1","hive_config":{"server":"https://hive.example.com"}}`)
+	})
+	mux.HandleFunc("/api/clusters_mgmt/v1/clusters/123/upgrade_policies", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"UpgradePolicyList","items":[{"kind":"UpgradePolicy","id":"policy-1","version":"4.14.1"}]}`)
+	})
+
+	connection, cleanup := sdktest.NewConnection(t, mux)
+	defer cleanup()
+
+	cluster := newTestCluster(t, "123")
+	enricher := NewParallelEnricher(
+		&SubscriptionEnricher{},
+		&ShardEnricher{},
+		&UpgradePolicyEnricher{},
+	)
+
+	enriched, err := enricher.Enrich(connection, cluster)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if enriched.Subscription == nil || enriched.Subscription.ID() != "sub-123" {
+		t.Errorf("expected subscription to be enriched, got %#v", enriched.Subscription)
+	}
+	if enriched.Account == nil || enriched.Account.Username() != "jdoe" {
+		t.Errorf("expected account to be enriched, got %#v", enriched.Account)
+	}
+	if enriched.Shard != "https://hive.example.com" {
+		t.Errorf("expected shard to be enriched, got %q", enriched.Shard)
+	}
+	if len(enriched.UpgradePolicies) != 1 || enriched.UpgradePolicies[0].ID() != "policy-1" {
+		t.Errorf("expected one upgrade policy to be enriched, got %#v", enriched.UpgradePolicies)
+	}
+}
+
+func TestParallelEnricherCollectsErrorsButKeepsPartialData(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/accounts_mgmt/v1/subscriptions/sub-123", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"Subscription","id":"sub-123","creator":{"id":"account-1"}}`)
+	})
+	mux.HandleFunc("/api/accounts_mgmt/v1/accounts/account-1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	mux.HandleFunc("/api/clusters_mgmt/v1/clusters/123/provision_shard", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	mux.HandleFunc("/api/clusters_mgmt/v1/clusters/123/upgrade_policies", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"UpgradePolicyList","items":[]}`)
+	})
+
+	connection, cleanup := sdktest.NewConnection(t, mux)
+	defer cleanup()
+
+	cluster := newTestCluster(t, "123")
+	enricher := NewParallelEnricher(
+		&SubscriptionEnricher{},
+		&ShardEnricher{},
+		&UpgradePolicyEnricher{},
+	)
+
+	enriched, err := enricher.Enrich(connection, cluster)
+	if err == nil {
+		t.Fatal("expected an aggregated error from the failing shard fetch")
+	}
+	// The subscription enricher treats a 403 fetching the account as "no
+	// access", not a hard failure, while the shard enricher's 500 should
+	// surface. Subscription and upgrade policy data should still have come
+	// through.
+	if enriched.Subscription == nil {
+		t.Error("expected subscription data to survive the shard failure")
+	}
+	if len(enriched.UpgradePolicies) != 0 {
+		t.Errorf("expected an empty upgrade policy list, got %#v", enriched.UpgradePolicies)
+	}
+}
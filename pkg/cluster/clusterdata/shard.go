@@ -0,0 +1,41 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterdata
+
+import (
+	"fmt"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// ShardEnricher fetches the provision shard a cluster was installed on.
+type ShardEnricher struct{}
+
+// Enrich implements Enricher.
+func (e *ShardEnricher) Enrich(connection *sdk.Connection, cluster *cmv1.Cluster, enriched *EnrichedCluster) error {
+	response, err := connection.ClustersMgmt().V1().Clusters().
+		Cluster(cluster.ID()).
+		ProvisionShard().
+		Get().
+		Send()
+	if err != nil {
+		return fmt.Errorf("can't get provision shard for cluster '%s': %v", cluster.ID(), err)
+	}
+	enriched.Shard = response.Body().HiveConfig().Server()
+	return nil
+}
@@ -0,0 +1,68 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterdata
+
+import (
+	"fmt"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// SubscriptionEnricher fetches the subscription backing a cluster, along with
+// the account that created it. Account lookup is folded in here rather than
+// left to its own enricher because it needs the subscription's creator ID, so
+// splitting it out would just mean fetching the same subscription twice.
+type SubscriptionEnricher struct{}
+
+// Enrich implements Enricher.
+func (e *SubscriptionEnricher) Enrich(connection *sdk.Connection, cluster *cmv1.Cluster, enriched *EnrichedCluster) error {
+	subID := cluster.Subscription().ID()
+	if subID == "" {
+		return nil
+	}
+	subResponse, err := connection.AccountsMgmt().V1().
+		Subscriptions().
+		Subscription(subID).
+		Get().
+		Send()
+	if err != nil {
+		if subResponse != nil && subResponse.Status() == 404 {
+			return nil
+		}
+		return fmt.Errorf("can't get subscription '%s': %v", subID, err)
+	}
+	enriched.Subscription = subResponse.Body()
+
+	accountID := subResponse.Body().Creator().ID()
+	if accountID == "" {
+		return nil
+	}
+	accountResponse, err := connection.AccountsMgmt().V1().
+		Accounts().
+		Account(accountID).
+		Get().
+		Send()
+	if err != nil {
+		if accountResponse != nil && (accountResponse.Status() == 404 || accountResponse.Status() == 403) {
+			return nil
+		}
+		return fmt.Errorf("can't get account '%s': %v", accountID, err)
+	}
+	enriched.Account = accountResponse.Body()
+	return nil
+}
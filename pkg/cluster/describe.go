@@ -17,100 +17,215 @@ limitations under the License.
 package cluster
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 
 	sdk "github.com/openshift-online/ocm-sdk-go"
-	amv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"gopkg.in/yaml.v2"
+
+	"github.com/openshift-online/ocm-cli/pkg/cluster/clusterdata"
+	"github.com/openshift-online/ocm-cli/pkg/clusterhealth"
 )
 
 const (
 	notAvailable string = "N/A"
+
+	// OutputTable renders a ClusterDescription as the classic human-readable table.
+	OutputTable string = "table"
+	// OutputJSON renders a ClusterDescription as JSON.
+	OutputJSON string = "json"
+	// OutputYAML renders a ClusterDescription as YAML.
+	OutputYAML string = "yaml"
 )
 
-func PrintClusterDesctipion(connection *sdk.Connection, cluster *cmv1.Cluster) error {
-	// Get API URL:
+// ClusterDescription is the stable, versioned representation of the output of
+// `ocm describe cluster`. Fields that OCM may not be able to populate (for
+// example because the caller lacks permission on the account, or the cluster
+// has no shard yet) are nullable rather than using string sentinels, so that
+// JSON/YAML consumers don't have to special-case "N/A".
+type ClusterDescription struct {
+	ID           string         `json:"id" yaml:"id"`
+	ExternalID   string         `json:"external_id" yaml:"external_id"`
+	Name         string         `json:"name" yaml:"name"`
+	APIURL       string         `json:"api_url" yaml:"api_url"`
+	APIListening string         `json:"api_listening" yaml:"api_listening"`
+	ConsoleURL   string         `json:"console_url" yaml:"console_url"`
+	Masters      int            `json:"masters" yaml:"masters"`
+	Infra        int            `json:"infra" yaml:"infra"`
+	Computes     int            `json:"computes" yaml:"computes"`
+	Product      string         `json:"product" yaml:"product"`
+	Provider     string         `json:"provider" yaml:"provider"`
+	Version      string         `json:"version" yaml:"version"`
+	Region       string         `json:"region" yaml:"region"`
+	MultiAZ      bool           `json:"multi_az" yaml:"multi_az"`
+	CCS          bool           `json:"ccs" yaml:"ccs"`
+	ChannelGroup string         `json:"channel_group" yaml:"channel_group"`
+	ClusterAdmin bool           `json:"cluster_admin" yaml:"cluster_admin"`
+	Organization *string        `json:"organization,omitempty" yaml:"organization,omitempty"`
+	Creator      *string        `json:"creator,omitempty" yaml:"creator,omitempty"`
+	Email        *string        `json:"email,omitempty" yaml:"email,omitempty"`
+	Created      time.Time      `json:"created" yaml:"created"`
+	Expiration   *time.Time     `json:"expiration,omitempty" yaml:"expiration,omitempty"`
+	Shard        *string        `json:"shard,omitempty" yaml:"shard,omitempty"`
+	NextUpgrade  *string        `json:"next_upgrade,omitempty" yaml:"next_upgrade,omitempty"`
+	Health       *HealthSummary `json:"health,omitempty" yaml:"health,omitempty"`
+}
+
+// HealthSummary is the printable form of a clusterhealth.Report.
+type HealthSummary struct {
+	State             string   `json:"state" yaml:"state"`
+	StuckProvisioning bool     `json:"stuck_provisioning" yaml:"stuck_provisioning"`
+	ProvisioningFor   string   `json:"provisioning_for,omitempty" yaml:"provisioning_for,omitempty"`
+	FailingChecks     []string `json:"failing_checks,omitempty" yaml:"failing_checks,omitempty"`
+	ChecksUnknown     bool     `json:"checks_unknown,omitempty" yaml:"checks_unknown,omitempty"`
+	FailingOperators  []string `json:"failing_operators,omitempty" yaml:"failing_operators,omitempty"`
+	OperatorsUnknown  bool     `json:"operators_unknown,omitempty" yaml:"operators_unknown,omitempty"`
+}
+
+// DescribeService fetches the data that makes up a ClusterDescription. It
+// exists so that the fetch logic can be reused by programmatic callers, not
+// just the CLI printer.
+type DescribeService struct {
+	Connection *sdk.Connection
+}
+
+// NewDescribeService creates a DescribeService bound to the given connection.
+func NewDescribeService(connection *sdk.Connection) *DescribeService {
+	return &DescribeService{Connection: connection}
+}
+
+// Describe fetches the subscription, account, provision shard and upgrade
+// policies for the given cluster concurrently via a clusterdata.ParallelEnricher
+// and assembles a ClusterDescription.
+func (s *DescribeService) Describe(cluster *cmv1.Cluster) (*ClusterDescription, error) {
 	api := cluster.API()
 	apiURL, _ := api.GetURL()
-	apiListening := api.Listening()
-
-	// Retrieve the details of the subscription:
-	var sub *amv1.Subscription
-	subID := cluster.Subscription().ID()
-	if subID != "" {
-		subResponse, err := connection.AccountsMgmt().V1().
-			Subscriptions().
-			Subscription(subID).
-			Get().
-			Send()
-		if err != nil {
-			if subResponse == nil || subResponse.Status() != 404 {
-				return fmt.Errorf(
-					"can't get subscription '%s': %v",
-					subID, err,
-				)
-			}
+
+	enricher := clusterdata.NewParallelEnricher(
+		&clusterdata.SubscriptionEnricher{},
+		&clusterdata.ShardEnricher{},
+		&clusterdata.UpgradePolicyEnricher{},
+	)
+	// A failure enriching one field (e.g. a 403 on the account, or a cluster
+	// with no shard yet) shouldn't hide the fields that did come back, so the
+	// aggregated error is intentionally not propagated here.
+	enriched, _ := enricher.Enrich(s.Connection, cluster)
+
+	description := &ClusterDescription{
+		ID:           cluster.ID(),
+		ExternalID:   cluster.ExternalID(),
+		Name:         fmt.Sprintf("%s.%s", cluster.Name(), cluster.DNS().BaseDomain()),
+		APIURL:       apiURL,
+		APIListening: string(api.Listening()),
+		ConsoleURL:   cluster.Console().URL(),
+		Masters:      cluster.Nodes().Master(),
+		Infra:        cluster.Nodes().Infra(),
+		Computes:     cluster.Nodes().Compute(),
+		Product:      cluster.Product().ID(),
+		Provider:     cluster.CloudProvider().ID(),
+		Version:      cluster.OpenshiftVersion(),
+		Region:       cluster.Region().ID(),
+		MultiAZ:      cluster.MultiAZ(),
+		CCS:          cluster.CCS().Enabled(),
+		ChannelGroup: cluster.Version().ChannelGroup(),
+		ClusterAdmin: cluster.ClusterAdminEnabled(),
+		Created:      cluster.CreationTimestamp().Round(time.Second),
+	}
+
+	if account := enriched.Account; account != nil {
+		if account.Organization() != nil && account.Organization().Name() != "" {
+			organization := account.Organization().Name()
+			description.Organization = &organization
 		}
-		sub = subResponse.Body()
-	}
-
-	// Retrieve the details of the account:
-	var account *amv1.Account
-	accountID := sub.Creator().ID()
-	if accountID != "" {
-		accountResponse, err := connection.AccountsMgmt().V1().
-			Accounts().
-			Account(accountID).
-			Get().
-			Send()
-		if err != nil {
-			if accountResponse == nil || (accountResponse.Status() != 404 &&
-				accountResponse.Status() != 403) {
-				return fmt.Errorf(
-					"can't get account '%s': %v",
-					accountID, err,
-				)
-			}
+		if username := account.Username(); username != "" {
+			description.Creator = &username
+		}
+		if email := account.Email(); email != "" {
+			description.Email = &email
 		}
-		account = accountResponse.Body()
 	}
 
-	// Find the details of the creator:
-	organization := notAvailable
-	if account.Organization() != nil && account.Organization().Name() != "" {
-		organization = account.Organization().Name()
+	if expiration := cluster.ExpirationTimestamp().Round(time.Second); !expiration.IsZero() {
+		description.Expiration = &expiration
 	}
 
-	creator := account.Username()
-	if creator == "" {
-		creator = notAvailable
+	if enriched.Shard != "" {
+		shard := enriched.Shard
+		description.Shard = &shard
 	}
 
-	email := account.Email()
-	if email == "" {
-		email = notAvailable
+	if upgrade := formatNextUpgrade(s.Connection, cluster.ID(), cluster.OpenshiftVersion(), enriched.UpgradePolicies); upgrade != "" {
+		description.NextUpgrade = &upgrade
 	}
 
-	// Find the details of the shard
-	shardPath, err := connection.ClustersMgmt().V1().Clusters().
-		Cluster(cluster.ID()).
-		ProvisionShard().
-		Get().
-		Send()
-	var shard string
-	if shardPath != nil && err == nil {
-		shard = shardPath.Body().HiveConfig().Server()
+	health, err := clusterhealth.Assess(s.Connection, cluster)
+	if err != nil {
+		// As with the other auxiliary fetches above, a failure assessing
+		// health should never blank out the rest of the describe output.
+		health = &clusterhealth.Report{ClusterState: cluster.State(), ChecksUnknown: true, OperatorsUnknown: true}
 	}
+	summary := &HealthSummary{
+		State:             string(health.ClusterState),
+		StuckProvisioning: health.StuckProvisioning,
+		FailingChecks:     health.FailingChecks,
+		ChecksUnknown:     health.ChecksUnknown,
+		FailingOperators:  health.FailingOperators,
+		OperatorsUnknown:  health.OperatorsUnknown,
+	}
+	if health.StuckProvisioning {
+		summary.ProvisioningFor = health.ProvisioningFor.String()
+	}
+	description.Health = summary
 
-	// Find the details of upgrade policies
-	upgrade := findNextUpgrade(connection, cluster.ID())
+	return description, nil
+}
 
-	// Print short cluster description:
+// PrintClusterDesctipion fetches the description of the given cluster and
+// prints it in the requested output format ("table", "json" or "yaml"). If
+// failOnDegraded is set and the cluster's health assessment finds it
+// degraded, an error is returned after printing so callers (e.g. CI) can
+// fail the build on a non-zero exit code.
+func PrintClusterDesctipion(connection *sdk.Connection, cluster *cmv1.Cluster, output string, failOnDegraded bool) error {
+	description, err := NewDescribeService(connection).Describe(cluster)
+	if err != nil {
+		return err
+	}
+
+	switch output {
+	case OutputJSON:
+		data, err := json.MarshalIndent(description, "", "  ")
+		if err != nil {
+			return fmt.Errorf("can't marshal cluster description: %v", err)
+		}
+		fmt.Println(string(data))
+	case OutputYAML:
+		data, err := yaml.Marshal(description)
+		if err != nil {
+			return fmt.Errorf("can't marshal cluster description: %v", err)
+		}
+		fmt.Println(string(data))
+	case OutputTable, "":
+		printTable(description)
+	default:
+		return fmt.Errorf("unsupported output format '%s', must be one of 'table', 'json' or 'yaml'", output)
+	}
+
+	if failOnDegraded && description.Health != nil &&
+		(description.Health.StuckProvisioning || len(description.Health.FailingChecks) > 0 ||
+			len(description.Health.FailingOperators) > 0) {
+		return fmt.Errorf("cluster '%s' is degraded", cluster.ID())
+	}
+
+	return nil
+}
+
+func printTable(description *ClusterDescription) {
 	fmt.Printf("\n"+
 		"ID:            %s\n"+
 		"External ID:   %s\n"+
-		"Name:          %s.%s\n"+
+		"Name:          %s\n"+
 		"API URL:       %s\n"+
 		"API Listening: %s\n"+
 		"Console URL:   %s\n"+
@@ -130,67 +245,103 @@ func PrintClusterDesctipion(connection *sdk.Connection, cluster *cmv1.Cluster) e
 		"Email:         %s\n"+
 		"Created:       %v\n"+
 		"Expiration:    %v\n",
-		cluster.ID(),
-		cluster.ExternalID(),
-		cluster.Name(),
-		cluster.DNS().BaseDomain(),
-		apiURL,
-		apiListening,
-		cluster.Console().URL(),
-		cluster.Nodes().Master(),
-		cluster.Nodes().Infra(),
-		cluster.Nodes().Compute(),
-		cluster.Product().ID(),
-		cluster.CloudProvider().ID(),
-		cluster.OpenshiftVersion(),
-		cluster.Region().ID(),
-		cluster.MultiAZ(),
-		cluster.CCS().Enabled(),
-		cluster.Version().ChannelGroup(),
-		cluster.ClusterAdminEnabled(),
-		organization,
-		creator,
-		email,
-		cluster.CreationTimestamp().Round(time.Second).Format(time.RFC3339Nano),
-		cluster.ExpirationTimestamp().Round(time.Second).Format(time.RFC3339Nano),
+		description.ID,
+		description.ExternalID,
+		description.Name,
+		description.APIURL,
+		description.APIListening,
+		description.ConsoleURL,
+		description.Masters,
+		description.Infra,
+		description.Computes,
+		description.Product,
+		description.Provider,
+		description.Version,
+		description.Region,
+		description.MultiAZ,
+		description.CCS,
+		description.ChannelGroup,
+		description.ClusterAdmin,
+		stringOrNotAvailable(description.Organization),
+		stringOrNotAvailable(description.Creator),
+		stringOrNotAvailable(description.Email),
+		description.Created.Format(time.RFC3339Nano),
+		timeOrNotAvailable(description.Expiration),
 	)
-	if shard != "" {
-		fmt.Printf("Shard:         %v\n", shard)
+	if description.Shard != nil {
+		fmt.Printf("Shard:         %v\n", *description.Shard)
 	}
-	if upgrade != "" {
-		fmt.Printf("Next Upgrade:  %v\n", upgrade)
+	if description.NextUpgrade != nil {
+		fmt.Printf("Next Upgrade:  %v\n", *description.NextUpgrade)
+	}
+	if health := description.Health; health != nil {
+		fmt.Printf("Health:\n  State: %s\n", health.State)
+		if health.StuckProvisioning {
+			fmt.Printf("  Stuck provisioning: %s\n", health.ProvisioningFor)
+		}
+		if len(health.FailingChecks) > 0 {
+			fmt.Println("  Failing checks:")
+			for _, check := range health.FailingChecks {
+				fmt.Printf("    - %s\n", check)
+			}
+		}
+		if health.ChecksUnknown {
+			fmt.Println("  Failing checks: unknown (couldn't list inflight checks)")
+		}
+		if len(health.FailingOperators) > 0 {
+			fmt.Println("  Failing operators:")
+			for _, operator := range health.FailingOperators {
+				fmt.Printf("    - %s\n", operator)
+			}
+		}
+		if health.OperatorsUnknown {
+			fmt.Println("  Failing operators: unknown (couldn't list cluster operators)")
+		}
 	}
 	fmt.Println()
+}
 
-	return nil
+func stringOrNotAvailable(value *string) string {
+	if value == nil {
+		return notAvailable
+	}
+	return *value
 }
 
-func findNextUpgrade(connection *sdk.Connection, id string) string {
-	upgradePolicies, err := connection.ClustersMgmt().V1().Clusters().Cluster(id).UpgradePolicies().List().Send()
-	if err != nil {
-		return ""
+func timeOrNotAvailable(value *time.Time) string {
+	if value == nil {
+		return notAvailable
 	}
-	if upgradePolicies.Items().Len() == 0 {
+	return value.Round(time.Second).Format(time.RFC3339Nano)
+}
+
+// formatNextUpgrade renders a human-readable summary of the nearest-in-time
+// upgrade policy in the given slice. The policy list and the cluster's
+// current version are passed in rather than fetched here, since callers (e.g.
+// DescribeService) already have both in hand and a fresh cluster Get() would
+// just be an extra round trip to read a value they already have.
+func formatNextUpgrade(connection *sdk.Connection, id string, currentVersion string, policies []*cmv1.UpgradePolicy) string {
+	if len(policies) == 0 {
 		return "none scheduled"
 	}
 
-	var nearestUpgradePolicy = upgradePolicies.Items().Get(0)
-	for _, uc := range upgradePolicies.Items().Slice() {
-		if uc.NextRun().Before(nearestUpgradePolicy.NextRun()) {
-			nearestUpgradePolicy = uc
-		}
+	nearest := nearestUpgradePolicy(policies)
+
+	streamLabel := ""
+	if stream, err := classifyUpgradeStream(currentVersion, nearest.Version()); err == nil {
+		streamLabel = fmt.Sprintf(" (%s)", stream)
 	}
 
-	policyState, err := connection.ClustersMgmt().V1().Clusters().Cluster(id).UpgradePolicies().UpgradePolicy(nearestUpgradePolicy.ID()).State().Get().Send()
+	policyState, err := connection.ClustersMgmt().V1().Clusters().Cluster(id).UpgradePolicies().UpgradePolicy(nearest.ID()).State().Get().Send()
 	if err != nil {
-		return fmt.Sprintf("version %s at %s", nearestUpgradePolicy.Version(), nearestUpgradePolicy.NextRun().Format(time.RFC3339))
+		return fmt.Sprintf("version %s%s at %s", nearest.Version(), streamLabel, nearest.NextRun().Format(time.RFC3339))
 	}
 
 	duration := ""
-	if time.Now().Before(nearestUpgradePolicy.NextRun()) {
-		d := nearestUpgradePolicy.NextRun().Sub(time.Now().UTC()).Truncate(1 * time.Minute)
+	if time.Now().Before(nearest.NextRun()) {
+		d := nearest.NextRun().Sub(time.Now().UTC()).Truncate(1 * time.Minute)
 		duration = fmt.Sprintf("(%s from now)", d)
 	}
-	response := fmt.Sprintf("%s for version %s at %s %s", policyState.Body().Value(),nearestUpgradePolicy.Version(), nearestUpgradePolicy.NextRun().Format(time.RFC3339), duration)
+	response := fmt.Sprintf("%s for version %s%s at %s %s", policyState.Body().Value(), nearest.Version(), streamLabel, nearest.NextRun().Format(time.RFC3339), duration)
 	return response
-}
\ No newline at end of file
+}
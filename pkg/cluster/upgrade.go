@@ -0,0 +1,126 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// GetUpgradePolicies returns every classic (non-HCP) upgrade policy configured
+// for the given cluster.
+func GetUpgradePolicies(connection *sdk.Connection, cluster *cmv1.Cluster) ([]*cmv1.UpgradePolicy, error) {
+	if cluster.Hypershift().Enabled() {
+		return nil, fmt.Errorf("cluster '%s' is an HCP cluster: upgrade policies are not supported, "+
+			"use 'ocm cluster upgrade available' to see node pool upgrade eligibility instead", cluster.ID())
+	}
+	response, err := connection.ClustersMgmt().V1().Clusters().
+		Cluster(cluster.ID()).
+		UpgradePolicies().
+		List().
+		Send()
+	if err != nil {
+		return nil, fmt.Errorf("can't list upgrade policies for cluster '%s': %v", cluster.ID(), err)
+	}
+	return response.Items().Slice(), nil
+}
+
+// nearestUpgradePolicy returns the policy with the soonest scheduled run time,
+// or nil if the given slice is empty.
+func nearestUpgradePolicy(policies []*cmv1.UpgradePolicy) *cmv1.UpgradePolicy {
+	if len(policies) == 0 {
+		return nil
+	}
+	nearest := policies[0]
+	for _, policy := range policies[1:] {
+		if policy.NextRun().Before(nearest.NextRun()) {
+			nearest = policy
+		}
+	}
+	return nearest
+}
+
+// ScheduleUpgradePolicy creates a new upgrade policy for the given cluster and
+// returns the policy as accepted by OCM.
+func ScheduleUpgradePolicy(connection *sdk.Connection, cluster *cmv1.Cluster, version string,
+	nextRun time.Time, scheduleType cmv1.ScheduleTypeValue) (*cmv1.UpgradePolicy, error) {
+	if cluster.Hypershift().Enabled() {
+		return nil, fmt.Errorf("cluster '%s' is an HCP cluster: upgrade policies are not supported, "+
+			"use 'ocm cluster upgrade available' to see node pool upgrade eligibility instead", cluster.ID())
+	}
+	policy, err := cmv1.NewUpgradePolicy().
+		Version(version).
+		NextRun(nextRun).
+		ScheduleType(scheduleType).
+		UpgradeType(cmv1.UpgradeTypeOSD).
+		Build()
+	if err != nil {
+		return nil, fmt.Errorf("can't build upgrade policy: %v", err)
+	}
+	response, err := connection.ClustersMgmt().V1().Clusters().
+		Cluster(cluster.ID()).
+		UpgradePolicies().
+		Add().
+		Body(policy).
+		Send()
+	if err != nil {
+		return nil, fmt.Errorf("can't schedule upgrade policy for cluster '%s': %v", cluster.ID(), err)
+	}
+	return response.Body(), nil
+}
+
+// CancelUpgradePolicy cancels the upgrade policy with the given ID. If id is
+// empty, the nearest-in-time scheduled policy is cancelled instead. It
+// returns the policy that was cancelled.
+func CancelUpgradePolicy(connection *sdk.Connection, cluster *cmv1.Cluster, id string) (*cmv1.UpgradePolicy, error) {
+	policies, err := GetUpgradePolicies(connection, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	var target *cmv1.UpgradePolicy
+	if id == "" {
+		target = nearestUpgradePolicy(policies)
+		if target == nil {
+			return nil, fmt.Errorf("cluster '%s' has no scheduled upgrade policies", cluster.ID())
+		}
+	} else {
+		for _, policy := range policies {
+			if policy.ID() == id {
+				target = policy
+				break
+			}
+		}
+		if target == nil {
+			return nil, fmt.Errorf("cluster '%s' has no upgrade policy with id '%s'", cluster.ID(), id)
+		}
+	}
+
+	_, err = connection.ClustersMgmt().V1().Clusters().
+		Cluster(cluster.ID()).
+		UpgradePolicies().
+		UpgradePolicy(target.ID()).
+		Delete().
+		Send()
+	if err != nil {
+		return nil, fmt.Errorf("can't cancel upgrade policy '%s' for cluster '%s': %v", target.ID(), cluster.ID(), err)
+	}
+	return target, nil
+}
@@ -0,0 +1,177 @@
+package cluster
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/openshift-online/ocm-cli/internal/sdktest"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+func newTestCluster(t *testing.T, id string, hypershift bool) *cmv1.Cluster {
+	t.Helper()
+
+	cluster, err := cmv1.NewCluster().
+		ID(id).
+		Hypershift(cmv1.NewHypershift().Enabled(hypershift)).
+		Build()
+	if err != nil {
+		t.Fatalf("can't build test cluster: %v", err)
+	}
+	return cluster
+}
+
+func newTestPolicy(t *testing.T, id, version string, nextRun time.Time) *cmv1.UpgradePolicy {
+	t.Helper()
+
+	policy, err := cmv1.NewUpgradePolicy().
+		ID(id).
+		Version(version).
+		NextRun(nextRun).
+		ScheduleType(cmv1.ScheduleTypeManual).
+		UpgradeType(cmv1.UpgradeTypeOSD).
+		Build()
+	if err != nil {
+		t.Fatalf("can't build test upgrade policy: %v", err)
+	}
+	return policy
+}
+
+func TestNearestUpgradePolicy(t *testing.T) {
+	now := time.Now()
+	soon := newTestPolicy(t, "soon", "4.14.1", now.Add(time.Hour))
+	later := newTestPolicy(t, "later", "4.14.2", now.Add(24*time.Hour))
+
+	nearest := nearestUpgradePolicy([]*cmv1.UpgradePolicy{later, soon})
+	if nearest.ID() != "soon" {
+		t.Errorf("expected the nearer-in-time policy 'soon', got '%s'", nearest.ID())
+	}
+}
+
+func TestNearestUpgradePolicyEmpty(t *testing.T) {
+	if nearest := nearestUpgradePolicy(nil); nearest != nil {
+		t.Errorf("expected nil for an empty slice, got %#v", nearest)
+	}
+}
+
+func TestScheduleUpgradePolicy(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/clusters_mgmt/v1/clusters/123/upgrade_policies", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected a POST, got %s", r.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"UpgradePolicy","id":"policy-1","version":"4.14.1",`+
+			`"next_run":"2030-01-01T00:00:00Z","schedule_type":"manual","upgrade_type":"OSD"}`)
+	})
+	connection, cleanup := sdktest.NewConnection(t, mux)
+	defer cleanup()
+
+	nextRun, err := time.Parse(time.RFC3339, "2030-01-01T00:00:00Z")
+	if err != nil {
+		t.Fatalf("can't parse test time: %v", err)
+	}
+
+	cluster := newTestCluster(t, "123", false)
+	policy, err := ScheduleUpgradePolicy(connection, cluster, "4.14.1", nextRun, cmv1.ScheduleTypeManual)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if policy.ID() != "policy-1" || policy.Version() != "4.14.1" {
+		t.Errorf("unexpected policy returned: %#v", policy)
+	}
+}
+
+func TestCancelUpgradePolicyByID(t *testing.T) {
+	now := time.Now()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/clusters_mgmt/v1/clusters/123/upgrade_policies", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"UpgradePolicyList","items":[`+
+			`{"kind":"UpgradePolicy","id":"policy-a","version":"4.14.1","next_run":"`+now.Add(time.Hour).Format(time.RFC3339)+`"},`+
+			`{"kind":"UpgradePolicy","id":"policy-b","version":"4.14.2","next_run":"`+now.Add(2*time.Hour).Format(time.RFC3339)+`"}`+
+			`]}`)
+	})
+	mux.HandleFunc("/api/clusters_mgmt/v1/clusters/123/upgrade_policies/policy-b", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("expected a DELETE, got %s", r.Method)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	connection, cleanup := sdktest.NewConnection(t, mux)
+	defer cleanup()
+
+	cluster := newTestCluster(t, "123", false)
+	policy, err := CancelUpgradePolicy(connection, cluster, "policy-b")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if policy.ID() != "policy-b" {
+		t.Errorf("expected to cancel 'policy-b', got '%s'", policy.ID())
+	}
+}
+
+func TestCancelUpgradePolicyNearestFallback(t *testing.T) {
+	now := time.Now()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/clusters_mgmt/v1/clusters/123/upgrade_policies", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"UpgradePolicyList","items":[`+
+			`{"kind":"UpgradePolicy","id":"policy-a","version":"4.14.1","next_run":"`+now.Add(2*time.Hour).Format(time.RFC3339)+`"},`+
+			`{"kind":"UpgradePolicy","id":"policy-b","version":"4.14.2","next_run":"`+now.Add(time.Hour).Format(time.RFC3339)+`"}`+
+			`]}`)
+	})
+	mux.HandleFunc("/api/clusters_mgmt/v1/clusters/123/upgrade_policies/policy-b", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	connection, cleanup := sdktest.NewConnection(t, mux)
+	defer cleanup()
+
+	cluster := newTestCluster(t, "123", false)
+	policy, err := CancelUpgradePolicy(connection, cluster, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if policy.ID() != "policy-b" {
+		t.Errorf("expected the nearest-in-time policy 'policy-b' to be cancelled, got '%s'", policy.ID())
+	}
+}
+
+func TestCancelUpgradePolicyNotFound(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/clusters_mgmt/v1/clusters/123/upgrade_policies", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"UpgradePolicyList","items":[]}`)
+	})
+	connection, cleanup := sdktest.NewConnection(t, mux)
+	defer cleanup()
+
+	cluster := newTestCluster(t, "123", false)
+	if _, err := CancelUpgradePolicy(connection, cluster, ""); err == nil {
+		t.Fatal("expected an error for a cluster with no scheduled upgrade policies")
+	}
+
+	if _, err := CancelUpgradePolicy(connection, cluster, "does-not-exist"); err == nil {
+		t.Fatal("expected an error for an unknown upgrade policy id")
+	}
+}
+
+func TestUpgradePoliciesRejectHCPCluster(t *testing.T) {
+	mux := http.NewServeMux()
+	connection, cleanup := sdktest.NewConnection(t, mux)
+	defer cleanup()
+
+	cluster := newTestCluster(t, "123", true)
+
+	if _, err := GetUpgradePolicies(connection, cluster); err == nil {
+		t.Fatal("expected an error listing upgrade policies for an HCP cluster")
+	}
+	if _, err := ScheduleUpgradePolicy(connection, cluster, "4.14.1", time.Now(), cmv1.ScheduleTypeManual); err == nil {
+		t.Fatal("expected an error scheduling an upgrade policy for an HCP cluster")
+	}
+	if _, err := CancelUpgradePolicy(connection, cluster, ""); err == nil {
+		t.Fatal("expected an error cancelling an upgrade policy for an HCP cluster")
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// UpgradeStream classifies how far an upgrade moves a cluster along the
+// OpenShift release stream.
+type UpgradeStream string
+
+const (
+	// ZStream upgrades bump only the patch version (e.g. 4.14.0 -> 4.14.1).
+	ZStream UpgradeStream = "z-stream"
+	// YStream upgrades bump the minor (or major) version (e.g. 4.14.1 -> 4.15.0).
+	YStream UpgradeStream = "y-stream"
+)
+
+// AvailableUpgrade is one version a cluster could be upgraded to, labelled
+// with whether it is a Y-stream or Z-stream move from the current version.
+type AvailableUpgrade struct {
+	Version string
+	Stream  UpgradeStream
+}
+
+// NodePoolUpgradeStatus reports whether a Hypershift node pool is running a
+// different version from its control plane and is therefore eligible for its
+// own upgrade. HCP clusters version node pools independently of the control
+// plane, so this is tracked separately from AvailableUpgrade.
+type NodePoolUpgradeStatus struct {
+	ID              string
+	Version         string
+	ControlPlane    string
+	UpgradeEligible bool
+}
+
+// classifyUpgradeStream compares a target version against the cluster's
+// current version and reports whether it is a Y-stream (minor/major) or
+// Z-stream (patch) upgrade.
+func classifyUpgradeStream(current, target string) (UpgradeStream, error) {
+	currentMajor, currentMinor, _, err := parseOpenshiftVersion(current)
+	if err != nil {
+		return "", err
+	}
+	targetMajor, targetMinor, _, err := parseOpenshiftVersion(target)
+	if err != nil {
+		return "", err
+	}
+	if targetMajor != currentMajor || targetMinor != currentMinor {
+		return YStream, nil
+	}
+	return ZStream, nil
+}
+
+// parseOpenshiftVersion extracts the major, minor and patch numbers from an
+// OpenShift version string such as "4.14.1" or "openshift-v4.14.1-rc.2".
+func parseOpenshiftVersion(version string) (major, minor, patch int, err error) {
+	version = strings.TrimPrefix(version, "openshift-v")
+	version = strings.SplitN(version, "-", 2)[0]
+	fields := strings.Split(version, ".")
+	if len(fields) < 2 {
+		return 0, 0, 0, fmt.Errorf("can't parse version '%s'", version)
+	}
+	major, err = strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("can't parse version '%s': %v", version, err)
+	}
+	minor, err = strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("can't parse version '%s': %v", version, err)
+	}
+	if len(fields) > 2 {
+		patch, err = strconv.Atoi(fields[2])
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("can't parse version '%s': %v", version, err)
+		}
+	}
+	return major, minor, patch, nil
+}
+
+// ListAvailableUpgrades returns every version the cluster could be upgraded
+// to, labelled with its upgrade stream relative to the cluster's current
+// version.
+func ListAvailableUpgrades(connection *sdk.Connection, cluster *cmv1.Cluster) ([]AvailableUpgrade, error) {
+	versionID := cluster.Version().ID()
+	if versionID == "" {
+		return nil, fmt.Errorf("cluster '%s' has no version information", cluster.ID())
+	}
+	response, err := connection.ClustersMgmt().V1().Versions().Version(versionID).Get().Send()
+	if err != nil {
+		return nil, fmt.Errorf("can't get version '%s': %v", versionID, err)
+	}
+
+	current := cluster.OpenshiftVersion()
+	var available []AvailableUpgrade
+	for _, target := range response.Body().AvailableUpgrades() {
+		stream, err := classifyUpgradeStream(current, target)
+		if err != nil {
+			// Skip versions we can't parse rather than failing the whole
+			// listing over one malformed entry.
+			continue
+		}
+		available = append(available, AvailableUpgrade{Version: target, Stream: stream})
+	}
+	return available, nil
+}
+
+// ListNodePoolUpgrades reports, for an HCP/Hypershift cluster, whether each
+// node pool is behind the control plane version and is thus eligible for its
+// own upgrade. Non-HCP clusters have no independently versioned node pools
+// and return an empty list.
+func ListNodePoolUpgrades(connection *sdk.Connection, cluster *cmv1.Cluster) ([]NodePoolUpgradeStatus, error) {
+	if !cluster.Hypershift().Enabled() {
+		return nil, nil
+	}
+
+	response, err := connection.ClustersMgmt().V1().Clusters().
+		Cluster(cluster.ID()).
+		NodePools().
+		List().
+		Send()
+	if err != nil {
+		return nil, fmt.Errorf("can't list node pools for cluster '%s': %v", cluster.ID(), err)
+	}
+
+	controlPlane := cluster.OpenshiftVersion()
+	var statuses []NodePoolUpgradeStatus
+	for _, pool := range response.Items().Slice() {
+		// pool.Version() is a resource reference whose ID() may be the prefixed
+		// resource ID (e.g. "openshift-v4.14.1"), not the plain raw version
+		// cluster.OpenshiftVersion() returns. RawID() is the comparable form
+		// when set; otherwise the prefix is stripped from ID().
+		version := pool.Version().RawID()
+		if version == "" {
+			version = strings.TrimPrefix(pool.Version().ID(), "openshift-v")
+		}
+		statuses = append(statuses, NodePoolUpgradeStatus{
+			ID:              pool.ID(),
+			Version:         version,
+			ControlPlane:    controlPlane,
+			UpgradeEligible: version != "" && version != controlPlane,
+		})
+	}
+	return statuses, nil
+}
@@ -0,0 +1,166 @@
+package cluster
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/openshift-online/ocm-cli/internal/sdktest"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+func TestParseOpenshiftVersion(t *testing.T) {
+	cases := []struct {
+		version             string
+		major, minor, patch int
+		wantErr             bool
+	}{
+		{version: "4.14.1", major: 4, minor: 14, patch: 1},
+		{version: "4.14", major: 4, minor: 14, patch: 0},
+		{version: "openshift-v4.14.1-rc.2", major: 4, minor: 14, patch: 1},
+		{version: "openshift-v4.15", major: 4, minor: 15, patch: 0},
+		{version: "4", wantErr: true},
+		{version: "not-a-version", wantErr: true},
+		{version: "4.x.1", wantErr: true},
+	}
+
+	for _, c := range cases {
+		major, minor, patch, err := parseOpenshiftVersion(c.version)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("parseOpenshiftVersion(%q): expected an error, got none", c.version)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseOpenshiftVersion(%q): unexpected error: %v", c.version, err)
+			continue
+		}
+		if major != c.major || minor != c.minor || patch != c.patch {
+			t.Errorf("parseOpenshiftVersion(%q) = (%d, %d, %d), want (%d, %d, %d)",
+				c.version, major, minor, patch, c.major, c.minor, c.patch)
+		}
+	}
+}
+
+func TestClassifyUpgradeStream(t *testing.T) {
+	cases := []struct {
+		current, target string
+		want            UpgradeStream
+		wantErr         bool
+	}{
+		{current: "4.14.1", target: "4.14.2", want: ZStream},
+		{current: "4.14.1", target: "4.15.0", want: YStream},
+		{current: "4.14.1", target: "5.0.0", want: YStream},
+		{current: "4.14.1", target: "not-a-version", wantErr: true},
+		{current: "not-a-version", target: "4.14.2", wantErr: true},
+	}
+
+	for _, c := range cases {
+		stream, err := classifyUpgradeStream(c.current, c.target)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("classifyUpgradeStream(%q, %q): expected an error, got none", c.current, c.target)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("classifyUpgradeStream(%q, %q): unexpected error: %v", c.current, c.target, err)
+			continue
+		}
+		if stream != c.want {
+			t.Errorf("classifyUpgradeStream(%q, %q) = %q, want %q", c.current, c.target, stream, c.want)
+		}
+	}
+}
+
+func TestListNodePoolUpgradesNonHCP(t *testing.T) {
+	cluster, err := cmv1.NewCluster().ID("123").Build()
+	if err != nil {
+		t.Fatalf("can't build test cluster: %v", err)
+	}
+
+	statuses, err := ListNodePoolUpgrades(nil, cluster)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if statuses != nil {
+		t.Errorf("expected no node pool statuses for a non-HCP cluster, got %#v", statuses)
+	}
+}
+
+func TestListNodePoolUpgradesEligibility(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/clusters_mgmt/v1/clusters/123/node_pools", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"NodePoolList","items":[`+
+			`{"kind":"NodePool","id":"behind","version":{"id":"4.14.1"}},`+
+			`{"kind":"NodePool","id":"current","version":{"id":"4.14.2"}}`+
+			`]}`)
+	})
+	connection, cleanup := sdktest.NewConnection(t, mux)
+	defer cleanup()
+
+	cluster, err := cmv1.NewCluster().
+		ID("123").
+		Hypershift(cmv1.NewHypershift().Enabled(true)).
+		OpenshiftVersion("4.14.2").
+		Build()
+	if err != nil {
+		t.Fatalf("can't build test cluster: %v", err)
+	}
+
+	statuses, err := ListNodePoolUpgrades(connection, cluster)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(statuses) != 2 {
+		t.Fatalf("expected 2 node pool statuses, got %d", len(statuses))
+	}
+	for _, status := range statuses {
+		switch status.ID {
+		case "behind":
+			if !status.UpgradeEligible {
+				t.Error("expected the node pool behind the control plane to be upgrade-eligible")
+			}
+		case "current":
+			if status.UpgradeEligible {
+				t.Error("expected the node pool matching the control plane to not be upgrade-eligible")
+			}
+		}
+	}
+}
+
+func TestListNodePoolUpgradesEligibilityWithPrefixedVersionID(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/clusters_mgmt/v1/clusters/123/node_pools", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"NodePoolList","items":[`+
+			`{"kind":"NodePool","id":"current","version":{"id":"openshift-v4.14.2"}}`+
+			`]}`)
+	})
+	connection, cleanup := sdktest.NewConnection(t, mux)
+	defer cleanup()
+
+	cluster, err := cmv1.NewCluster().
+		ID("123").
+		Hypershift(cmv1.NewHypershift().Enabled(true)).
+		OpenshiftVersion("4.14.2").
+		Build()
+	if err != nil {
+		t.Fatalf("can't build test cluster: %v", err)
+	}
+
+	statuses, err := ListNodePoolUpgrades(connection, cluster)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(statuses) != 1 {
+		t.Fatalf("expected 1 node pool status, got %d", len(statuses))
+	}
+	// A node pool already on the control plane's version, reported with the
+	// real API's prefixed resource ID, must not be flagged as eligible.
+	if statuses[0].UpgradeEligible {
+		t.Error("expected a node pool on the control plane's version to not be upgrade-eligible, even with a prefixed version ID")
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright (c) 2019 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clusterhealth assesses the health of a cluster beyond its raw
+// state, surfacing conditions an operator would otherwise have to notice by
+// hand: provisioning that has been running unusually long, inflight checks
+// (upgrade gates) blocking an install or upgrade from completing, and
+// cluster operators reporting degraded.
+package clusterhealth
+
+import (
+	"time"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// stuckProvisioningThreshold is how long a cluster can sit in "installing"
+// before it is reported as stuck.
+const stuckProvisioningThreshold = 90 * time.Minute
+
+// Report summarizes the health of a cluster at the time it was assessed.
+type Report struct {
+	ClusterState      cmv1.ClusterState
+	StuckProvisioning bool
+	ProvisioningFor   time.Duration
+	FailingChecks     []string
+	// ChecksUnknown is set when the inflight checks for the cluster couldn't
+	// be retrieved for any reason (forbidden, not found, timeout, ...), so
+	// FailingChecks should be read as "unknown", not "none".
+	ChecksUnknown bool
+	// FailingOperators lists the cluster operators the clusters management
+	// API reports as degraded.
+	FailingOperators []string
+	// OperatorsUnknown is set when the cluster's operators couldn't be
+	// retrieved for any reason (forbidden, not found, timeout, ...), so
+	// FailingOperators should be read as "unknown", not "none".
+	OperatorsUnknown bool
+}
+
+// Degraded reports whether the cluster has an issue serious enough to fail a
+// `--fail-on-degraded` check.
+func (r *Report) Degraded() bool {
+	return r.StuckProvisioning || len(r.FailingChecks) > 0 || len(r.FailingOperators) > 0
+}
+
+// Assess fetches the cluster's inflight checks and cluster operators and
+// combines them with its current state and age to produce a Report. Fetch
+// failures are never fatal: they mark the corresponding field as unknown and
+// Assess still returns a usable Report, the same way
+// clusterdata.ParallelEnricher tolerates a failing enricher.
+func Assess(connection *sdk.Connection, cluster *cmv1.Cluster) (*Report, error) {
+	report := &Report{ClusterState: cluster.State()}
+
+	if cluster.State() == cmv1.ClusterStateInstalling {
+		elapsed := time.Since(cluster.CreationTimestamp())
+		if elapsed > stuckProvisioningThreshold {
+			report.StuckProvisioning = true
+			report.ProvisioningFor = elapsed.Round(time.Minute)
+		}
+	}
+
+	checks, err := connection.ClustersMgmt().V1().Clusters().
+		Cluster(cluster.ID()).
+		InflightChecks().
+		List().
+		Send()
+	if err != nil {
+		// Like the other auxiliary fetches describe relies on (see
+		// clusterdata.ParallelEnricher), a failure here just means we can't
+		// report on inflight checks, not that the whole health assessment -
+		// and the describe command along with it - should fail.
+		report.ChecksUnknown = true
+	} else {
+		for _, check := range checks.Items().Slice() {
+			if check.State() == cmv1.InflightCheckStateFailed {
+				report.FailingChecks = append(report.FailingChecks, check.Name())
+			}
+		}
+	}
+
+	operators, err := connection.ClustersMgmt().V1().Clusters().
+		Cluster(cluster.ID()).
+		ClusterOperators().
+		List().
+		Send()
+	if err != nil {
+		report.OperatorsUnknown = true
+	} else {
+		for _, operator := range operators.Items().Slice() {
+			if operator.Degraded() {
+				report.FailingOperators = append(report.FailingOperators, operator.Name())
+			}
+		}
+	}
+
+	return report, nil
+}
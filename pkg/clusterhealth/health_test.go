@@ -0,0 +1,233 @@
+package clusterhealth
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/openshift-online/ocm-cli/internal/sdktest"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+func TestAssessHealthyCluster(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/clusters_mgmt/v1/clusters/123/inflight_checks", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"InflightCheckList","items":[{"kind":"InflightCheck","name":"dns","state":"passed"}]}`)
+	})
+	connection, cleanup := sdktest.NewConnection(t, mux)
+	defer cleanup()
+
+	cluster, err := cmv1.NewCluster().
+		ID("123").
+		State(cmv1.ClusterStateReady).
+		Build()
+	if err != nil {
+		t.Fatalf("can't build test cluster: %v", err)
+	}
+
+	report, err := Assess(connection, cluster)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Degraded() {
+		t.Errorf("expected a healthy report, got %#v", report)
+	}
+}
+
+func TestAssessReportsFailingChecks(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/clusters_mgmt/v1/clusters/123/inflight_checks", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"InflightCheckList","items":[`+
+			`{"kind":"InflightCheck","name":"dns","state":"passed"},`+
+			`{"kind":"InflightCheck","name":"network_verifier","state":"failed"}`+
+			`]}`)
+	})
+	connection, cleanup := sdktest.NewConnection(t, mux)
+	defer cleanup()
+
+	cluster, err := cmv1.NewCluster().
+		ID("123").
+		State(cmv1.ClusterStateReady).
+		Build()
+	if err != nil {
+		t.Fatalf("can't build test cluster: %v", err)
+	}
+
+	report, err := Assess(connection, cluster)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !report.Degraded() {
+		t.Fatal("expected the failing inflight check to mark the cluster as degraded")
+	}
+	if len(report.FailingChecks) != 1 || report.FailingChecks[0] != "network_verifier" {
+		t.Errorf("expected only 'network_verifier' to be reported, got %#v", report.FailingChecks)
+	}
+}
+
+func TestAssessReportsStuckProvisioning(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/clusters_mgmt/v1/clusters/123/inflight_checks", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"InflightCheckList","items":[]}`)
+	})
+	connection, cleanup := sdktest.NewConnection(t, mux)
+	defer cleanup()
+
+	cluster, err := cmv1.NewCluster().
+		ID("123").
+		State(cmv1.ClusterStateInstalling).
+		CreationTimestamp(time.Now().Add(-2 * time.Hour)).
+		Build()
+	if err != nil {
+		t.Fatalf("can't build test cluster: %v", err)
+	}
+
+	report, err := Assess(connection, cluster)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !report.StuckProvisioning {
+		t.Error("expected a cluster installing for 2 hours to be reported as stuck")
+	}
+	if !report.Degraded() {
+		t.Error("expected a stuck-provisioning cluster to be degraded")
+	}
+}
+
+func TestAssessToleratesForbiddenInflightChecks(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/clusters_mgmt/v1/clusters/123/inflight_checks", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"kind":"Error","id":"403","reason":"not authorized"}`)
+	})
+	connection, cleanup := sdktest.NewConnection(t, mux)
+	defer cleanup()
+
+	cluster, err := cmv1.NewCluster().
+		ID("123").
+		State(cmv1.ClusterStateReady).
+		Build()
+	if err != nil {
+		t.Fatalf("can't build test cluster: %v", err)
+	}
+
+	report, err := Assess(connection, cluster)
+	if err != nil {
+		t.Fatalf("expected a forbidden inflight checks fetch to be tolerated, got error: %v", err)
+	}
+	if !report.ChecksUnknown {
+		t.Error("expected ChecksUnknown to be set")
+	}
+	if report.Degraded() {
+		t.Error("unknown checks should not count as degraded")
+	}
+}
+
+func TestAssessReportsFailingOperators(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/clusters_mgmt/v1/clusters/123/inflight_checks", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"InflightCheckList","items":[]}`)
+	})
+	mux.HandleFunc("/api/clusters_mgmt/v1/clusters/123/cluster_operators", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"ClusterOperatorList","items":[`+
+			`{"kind":"ClusterOperator","name":"authentication","degraded":false},`+
+			`{"kind":"ClusterOperator","name":"ingress","degraded":true}`+
+			`]}`)
+	})
+	connection, cleanup := sdktest.NewConnection(t, mux)
+	defer cleanup()
+
+	cluster, err := cmv1.NewCluster().
+		ID("123").
+		State(cmv1.ClusterStateReady).
+		Build()
+	if err != nil {
+		t.Fatalf("can't build test cluster: %v", err)
+	}
+
+	report, err := Assess(connection, cluster)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !report.Degraded() {
+		t.Fatal("expected the degraded cluster operator to mark the cluster as degraded")
+	}
+	if len(report.FailingOperators) != 1 || report.FailingOperators[0] != "ingress" {
+		t.Errorf("expected only 'ingress' to be reported, got %#v", report.FailingOperators)
+	}
+}
+
+func TestAssessToleratesForbiddenClusterOperators(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/clusters_mgmt/v1/clusters/123/inflight_checks", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"kind":"InflightCheckList","items":[]}`)
+	})
+	mux.HandleFunc("/api/clusters_mgmt/v1/clusters/123/cluster_operators", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"kind":"Error","id":"403","reason":"not authorized"}`)
+	})
+	connection, cleanup := sdktest.NewConnection(t, mux)
+	defer cleanup()
+
+	cluster, err := cmv1.NewCluster().
+		ID("123").
+		State(cmv1.ClusterStateReady).
+		Build()
+	if err != nil {
+		t.Fatalf("can't build test cluster: %v", err)
+	}
+
+	report, err := Assess(connection, cluster)
+	if err != nil {
+		t.Fatalf("expected a forbidden cluster operators fetch to be tolerated, got error: %v", err)
+	}
+	if !report.OperatorsUnknown {
+		t.Error("expected OperatorsUnknown to be set")
+	}
+	if report.Degraded() {
+		t.Error("unknown operators should not count as degraded")
+	}
+}
+
+func TestAssessToleratesServerErrors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/clusters_mgmt/v1/clusters/123/inflight_checks", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"kind":"Error","id":"500","reason":"internal error"}`)
+	})
+	mux.HandleFunc("/api/clusters_mgmt/v1/clusters/123/cluster_operators", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"kind":"Error","id":"500","reason":"internal error"}`)
+	})
+	connection, cleanup := sdktest.NewConnection(t, mux)
+	defer cleanup()
+
+	cluster, err := cmv1.NewCluster().
+		ID("123").
+		State(cmv1.ClusterStateReady).
+		Build()
+	if err != nil {
+		t.Fatalf("can't build test cluster: %v", err)
+	}
+
+	// A transient failure (timeout, 500, connection reset) must be tolerated
+	// just like a 403/404: describe cluster's output should never go blank
+	// because the health assessment couldn't reach one of its endpoints.
+	report, err := Assess(connection, cluster)
+	if err != nil {
+		t.Fatalf("expected a server error to be tolerated, got error: %v", err)
+	}
+	if !report.ChecksUnknown || !report.OperatorsUnknown {
+		t.Error("expected both ChecksUnknown and OperatorsUnknown to be set")
+	}
+	if report.Degraded() {
+		t.Error("unknown checks/operators should not count as degraded")
+	}
+}